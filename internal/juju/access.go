@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+// ReconcileAccess computes which subjects need to be granted or revoked to
+// move an access grant (on a model, an offer, or anything else keyed by a
+// set of subjects and an access level) from the old set of subjects to the
+// new one. Both the access model and the offer access resources share this,
+// since they only differ in how the resulting grant/revoke calls are made.
+func ReconcileAccess(oldSubjects, newSubjects []Subject) (added, removed []Subject) {
+	return missingFrom(newSubjects, oldSubjects), missingFrom(oldSubjects, newSubjects)
+}
+
+// ReconcileAccessUpdate computes the full Update plan for a subjects+access
+// resource: which subjects to grant, which to revoke, which access level to
+// apply it at, and whether anything changed at all. Both the access model
+// and the offer access resources share this, on top of ReconcileAccess, so
+// the "did the access level change, and if so re-grant everyone at the new
+// level" decision doesn't have to be duplicated in both resources' Update.
+func ReconcileAccessUpdate(oldSubjects, newSubjects []Subject, oldAccess, newAccess string) (grant, revoke []Subject, access string, changed bool) {
+	added, removed := ReconcileAccess(oldSubjects, newSubjects)
+	access = oldAccess
+	changed = len(added) > 0 || len(removed) > 0
+
+	accessChanged := oldAccess != newAccess
+	if accessChanged {
+		changed = true
+		access = newAccess
+	}
+
+	// When the access level itself changed, every subject that remains
+	// needs re-granting at the new level, not just the ones that were
+	// added.
+	grant = added
+	if accessChanged {
+		grant = newSubjects
+	}
+
+	return grant, removed, access, changed
+}
+
+// missingFrom returns the subjects in "of" that are not present in "from".
+func missingFrom(of, from []Subject) []Subject {
+	var missing []Subject
+	for _, subject := range of {
+		found := false
+		for _, other := range from {
+			if subject == other {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, subject)
+		}
+	}
+	return missing
+}
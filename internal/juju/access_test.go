@@ -0,0 +1,171 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestReconcileAccess(t *testing.T) {
+	alice := Subject{Kind: SubjectKindUser, Name: "alice"}
+	bob := Subject{Kind: SubjectKindUser, Name: "bob"}
+	carol := Subject{Kind: SubjectKindUser, Name: "carol"}
+	ops := Subject{Kind: SubjectKindGroup, Name: "ops"}
+
+	tests := []struct {
+		name        string
+		old, new    []Subject
+		wantAdded   []Subject
+		wantRemoved []Subject
+	}{
+		{
+			name: "no change",
+			old:  []Subject{alice, bob},
+			new:  []Subject{alice, bob},
+		},
+		{
+			name:      "subject added",
+			old:       []Subject{alice},
+			new:       []Subject{alice, bob},
+			wantAdded: []Subject{bob},
+		},
+		{
+			name:        "subject removed",
+			old:         []Subject{alice, bob},
+			new:         []Subject{alice},
+			wantRemoved: []Subject{bob},
+		},
+		{
+			name:        "subject added and removed",
+			old:         []Subject{alice, bob},
+			new:         []Subject{alice, carol},
+			wantAdded:   []Subject{carol},
+			wantRemoved: []Subject{bob},
+		},
+		{
+			name:      "mixed subject kinds",
+			old:       []Subject{alice},
+			new:       []Subject{alice, ops},
+			wantAdded: []Subject{ops},
+		},
+		{
+			name: "both empty",
+		},
+		{
+			name:      "old empty",
+			new:       []Subject{alice, bob},
+			wantAdded: []Subject{alice, bob},
+		},
+		{
+			name:        "new empty",
+			old:         []Subject{alice, bob},
+			wantRemoved: []Subject{alice, bob},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := ReconcileAccess(tt.old, tt.new)
+			if !sameSubjects(added, tt.wantAdded) {
+				t.Errorf("added = %v, want %v", added, tt.wantAdded)
+			}
+			if !sameSubjects(removed, tt.wantRemoved) {
+				t.Errorf("removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestReconcileAccessUpdate(t *testing.T) {
+	alice := Subject{Kind: SubjectKindUser, Name: "alice"}
+	bob := Subject{Kind: SubjectKindUser, Name: "bob"}
+
+	tests := []struct {
+		name                 string
+		old, new             []Subject
+		oldAccess, newAccess string
+		wantGrant            []Subject
+		wantRevoke           []Subject
+		wantAccess           string
+		wantChanged          bool
+	}{
+		{
+			name:       "no change",
+			old:        []Subject{alice},
+			new:        []Subject{alice},
+			oldAccess:  "read",
+			newAccess:  "read",
+			wantAccess: "read",
+		},
+		{
+			name:        "subject added, access unchanged",
+			old:         []Subject{alice},
+			new:         []Subject{alice, bob},
+			oldAccess:   "read",
+			newAccess:   "read",
+			wantGrant:   []Subject{bob},
+			wantAccess:  "read",
+			wantChanged: true,
+		},
+		{
+			name:        "subject removed, access unchanged",
+			old:         []Subject{alice, bob},
+			new:         []Subject{alice},
+			oldAccess:   "read",
+			newAccess:   "read",
+			wantRevoke:  []Subject{bob},
+			wantAccess:  "read",
+			wantChanged: true,
+		},
+		{
+			name:        "access changed re-grants every remaining subject",
+			old:         []Subject{alice, bob},
+			new:         []Subject{alice, bob},
+			oldAccess:   "read",
+			newAccess:   "write",
+			wantGrant:   []Subject{alice, bob},
+			wantAccess:  "write",
+			wantChanged: true,
+		},
+		{
+			name:        "access and subjects both changed",
+			old:         []Subject{alice, bob},
+			new:         []Subject{alice},
+			oldAccess:   "read",
+			newAccess:   "write",
+			wantGrant:   []Subject{alice},
+			wantRevoke:  []Subject{bob},
+			wantAccess:  "write",
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant, revoke, access, changed := ReconcileAccessUpdate(tt.old, tt.new, tt.oldAccess, tt.newAccess)
+			if !sameSubjects(grant, tt.wantGrant) {
+				t.Errorf("grant = %v, want %v", grant, tt.wantGrant)
+			}
+			if !sameSubjects(revoke, tt.wantRevoke) {
+				t.Errorf("revoke = %v, want %v", revoke, tt.wantRevoke)
+			}
+			if access != tt.wantAccess {
+				t.Errorf("access = %q, want %q", access, tt.wantAccess)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+		})
+	}
+}
+
+// sameSubjects compares two subject lists ignoring order.
+func sameSubjects(a, b []Subject) bool {
+	a, b = append([]Subject(nil), a...), append([]Subject(nil), b...)
+	sort.Slice(a, func(i, j int) bool { return a[i].Name < a[j].Name })
+	sort.Slice(b, func(i, j int) bool { return b[i].Name < b[j].Name })
+	return reflect.DeepEqual(a, b)
+}
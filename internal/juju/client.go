@@ -0,0 +1,50 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+// APICallCloser is the minimal surface the facade clients below need from an
+// open connection to a Juju controller, or to a specific model's facades.
+// It mirrors the shape of juju/juju's api.Connection so the facade clients
+// can be swapped onto the real thing without changing their call sites.
+type APICallCloser interface {
+	APICall(objType string, version int, id, request string, params, response interface{}) error
+	Close() error
+}
+
+// Dialer opens a connection to the controller, or to a specific model's
+// facades when modelUUID is non-nil.
+type Dialer interface {
+	Dial(modelUUID *string) (APICallCloser, error)
+}
+
+// SharedClient is embedded by each facade client so they all open
+// connections the same way.
+type SharedClient struct {
+	dialer Dialer
+}
+
+// GetConnection opens a connection to the controller, or to the model
+// identified by modelUUID when it is non-nil. Callers are responsible for
+// closing the returned connection.
+func (c SharedClient) GetConnection(modelUUID *string) (APICallCloser, error) {
+	return c.dialer.Dial(modelUUID)
+}
+
+// Client is the entry point the provider uses to talk to a Juju controller.
+type Client struct {
+	Models *modelsClient
+	Users  *usersClient
+	Offers *offersClient
+}
+
+// NewClient returns a Client that opens connections through the given
+// Dialer.
+func NewClient(dialer Dialer) *Client {
+	sc := SharedClient{dialer: dialer}
+	return &Client{
+		Models: newModelsClient(sc),
+		Users:  newUsersClient(sc),
+		Offers: newOffersClient(sc),
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+// SubjectKind identifies the kind of principal a model grant applies to.
+type SubjectKind string
+
+const (
+	// SubjectKindUser is a single Juju user account.
+	SubjectKindUser SubjectKind = "user"
+	// SubjectKindGroup is a group of users.
+	SubjectKindGroup SubjectKind = "group"
+	// SubjectKindServiceAccount is a non-interactive service account identity.
+	SubjectKindServiceAccount SubjectKind = "service_account"
+)
+
+// Subject identifies a principal a model grant applies to, by kind and name.
+type Subject struct {
+	Kind SubjectKind
+	Name string
+}
+
+// GrantModelInput contains the parameters for granting access to a model.
+type GrantModelInput struct {
+	User      string
+	Kind      SubjectKind
+	Access    string
+	ModelUUID string
+}
+
+// UpdateAccessModelInput contains the parameters for updating model access.
+type UpdateAccessModelInput struct {
+	ModelUUID string
+	Grant     []Subject
+	Revoke    []Subject
+	Access    string
+}
+
+// DestroyAccessModelInput contains the parameters for removing model access.
+type DestroyAccessModelInput struct {
+	ModelUUID string
+	Revoke    []Subject
+	Access    string
+}
+
+type modelsClient struct {
+	SharedClient
+}
+
+func newModelsClient(sc SharedClient) *modelsClient {
+	return &modelsClient{SharedClient: sc}
+}
+
+// ResolveModelUUID looks up the UUID of the model with the given name.
+func (c *modelsClient) ResolveModelUUID(model string) (string, error) {
+	conn, err := c.GetConnection(nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+
+	var result struct {
+		UUID string `json:"uuid"`
+	}
+	if err := conn.APICall("ModelManager", 9, "", "ModelUUID", map[string]string{"model": model}, &result); err != nil {
+		return "", err
+	}
+	return result.UUID, nil
+}
+
+// GrantModel grants a subject access to a model.
+func (c *modelsClient) GrantModel(input GrantModelInput) error {
+	conn, err := c.GetConnection(nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	kind := input.Kind
+	if kind == "" {
+		kind = SubjectKindUser
+	}
+	return conn.APICall("ModelManager", 9, "", "ModifyModelAccess", modifyModelAccessParams(input.User, kind, input.Access, input.ModelUUID, "grant"), nil)
+}
+
+// UpdateAccessModel grants and revokes model access in a single call.
+// Note we do a revoke against `read` to remove the subject from the model
+// access; if a subject has had `write`, removing that access would decrease
+// their access to `read` and the subject would remain part of the model
+// access.
+func (c *modelsClient) UpdateAccessModel(input UpdateAccessModelInput) error {
+	conn, err := c.GetConnection(nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	for _, subject := range input.Revoke {
+		if err := conn.APICall("ModelManager", 9, "", "ModifyModelAccess", modifyModelAccessParams(subject.Name, subject.Kind, "read", input.ModelUUID, "revoke"), nil); err != nil {
+			return err
+		}
+	}
+
+	for _, subject := range input.Grant {
+		if err := conn.APICall("ModelManager", 9, "", "ModifyModelAccess", modifyModelAccessParams(subject.Name, subject.Kind, input.Access, input.ModelUUID, "grant"), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DestroyAccessModel removes model access for the given subjects.
+// Note we do a revoke against `read` to remove the subject from the model
+// access; if a subject has had `write`, removing that access would decrease
+// their access to `read` and the subject would remain part of the model
+// access.
+func (c *modelsClient) DestroyAccessModel(input DestroyAccessModelInput) error {
+	conn, err := c.GetConnection(nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	for _, subject := range input.Revoke {
+		if err := conn.APICall("ModelManager", 9, "", "ModifyModelAccess", modifyModelAccessParams(subject.Name, subject.Kind, "read", input.ModelUUID, "revoke"), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func modifyModelAccessParams(name string, kind SubjectKind, access, modelUUID, action string) map[string]interface{} {
+	return map[string]interface{}{
+		"user":         name,
+		"subject-kind": kind,
+		"access":       access,
+		"model-uuid":   modelUUID,
+		"action":       action,
+	}
+}
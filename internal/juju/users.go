@@ -0,0 +1,45 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package juju
+
+// UserAccessPermission is the access level a user has been granted on a
+// model, e.g. "read", "write" or "admin".
+type UserAccessPermission string
+
+// ModelUserInfo describes a single user's access to a model.
+type ModelUserInfo struct {
+	UserName string
+	Access   UserAccessPermission
+}
+
+// ReadModelUserResponse contains the list of users with access to a model.
+type ReadModelUserResponse struct {
+	ModelUserInfo []ModelUserInfo
+}
+
+type usersClient struct {
+	SharedClient
+}
+
+func newUsersClient(sc SharedClient) *usersClient {
+	return &usersClient{SharedClient: sc}
+}
+
+// ModelUserInfo lists the users and their access level for the given model.
+func (c *usersClient) ModelUserInfo(modelUUID string) (*ReadModelUserResponse, error) {
+	conn, err := c.GetConnection(&modelUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	var result struct {
+		ModelUserInfo []ModelUserInfo `json:"model-user-info"`
+	}
+	if err := conn.APICall("UserManager", 3, "", "ModelUserInfo", map[string]string{"model-uuid": modelUUID}, &result); err != nil {
+		return nil, err
+	}
+
+	return &ReadModelUserResponse{ModelUserInfo: result.ModelUserInfo}, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ datasource.DataSourceWithConfigure = &modelAccessDataSource{}
+
+// NewModelAccessDataSource returns the juju_model_access data source.
+func NewModelAccessDataSource() datasource.DataSourceWithConfigure {
+	return &modelAccessDataSource{}
+}
+
+type modelAccessDataSource struct {
+	client *juju.Client
+}
+
+type modelAccessDataSourceModel struct {
+	Model   types.String               `tfsdk:"model"`
+	Users   []modelAccessUserDataModel `tfsdk:"users"`
+	Admins  types.List                 `tfsdk:"admins"`
+	Writers types.List                 `tfsdk:"writers"`
+	Readers types.List                 `tfsdk:"readers"`
+	// ID required by the testing framework
+	ID types.String `tfsdk:"id"`
+}
+
+type modelAccessUserDataModel struct {
+	User   types.String `tfsdk:"user"`
+	Access types.String `tfsdk:"access"`
+}
+
+// Metadata returns the full data source name as used in terraform plans.
+func (d *modelAccessDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_access"
+}
+
+// Schema returns the schema for the model access data source.
+func (d *modelAccessDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A data source reporting every user granted access to a Juju Model and their access level.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				Description: "The name of the model to report access for",
+				Required:    true,
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "Every user with access to the model, and their access level",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							Description: "The name of the user",
+							Computed:    true,
+						},
+						"access": schema.StringAttribute{
+							Description: "The access level granted to the user",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"admins": schema.ListAttribute{
+				Description: "Users with admin access to the model",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"writers": schema.ListAttribute{
+				Description: "Users with write access to the model",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"readers": schema.ListAttribute{
+				Description: "Users with read access to the model",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *modelAccessDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+// Read is called when the provider must read data source values in order to
+// update state. Config values should be read from the ReadRequest and new
+// state values set on the ReadResponse.
+func (d *modelAccessDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	var data modelAccessDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model := data.Model.ValueString()
+	uuid, err := d.client.Models.ResolveModelUUID(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve model %q, got error: %s", model, err))
+		return
+	}
+
+	response, err := d.client.Users.ModelUserInfo(uuid)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model access, got error: %s", err))
+		return
+	}
+
+	var admins, writers, readers []string
+	users := make([]modelAccessUserDataModel, 0, len(response.ModelUserInfo))
+	for _, modelUser := range response.ModelUserInfo {
+		users = append(users, modelAccessUserDataModel{
+			User:   types.StringValue(modelUser.UserName),
+			Access: types.StringValue(string(modelUser.Access)),
+		})
+		switch modelUser.Access {
+		case "admin":
+			admins = append(admins, modelUser.UserName)
+		case "write":
+			writers = append(writers, modelUser.UserName)
+		case "read":
+			readers = append(readers, modelUser.UserName)
+		}
+	}
+
+	adminsList, diags := types.ListValueFrom(ctx, types.StringType, admins)
+	resp.Diagnostics.Append(diags...)
+	writersList, diags := types.ListValueFrom(ctx, types.StringType, writers)
+	resp.Diagnostics.Append(diags...)
+	readersList, diags := types.ListValueFrom(ctx, types.StringType, readers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Users = users
+	data.Admins = adminsList
+	data.Writers = writersList
+	data.Readers = readersList
+	data.ID = types.StringValue(uuid)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
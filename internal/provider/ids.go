@@ -0,0 +1,49 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatCompositeID builds a structured resource ID of the form
+// "<keyName>=<value>,access=<access>". Unlike the earlier ad-hoc
+// "<value>:<access>" scheme, this survives colons in the value itself (for
+// example an offer URL of the form "controller:admin/default.offername"), and
+// qualified model names such as "user@domain/modelname" round-trip as well.
+func formatCompositeID(keyName, value, access string) string {
+	return fmt.Sprintf("%s=%s,access=%s", keyName, value, access)
+}
+
+// parseCompositeID parses an ID produced by formatCompositeID, returning the
+// value stored under keyName and the access level.
+func parseCompositeID(id, keyName string) (string, string, error) {
+	expected := fmt.Sprintf("'%s=<value>,access=<level>'", keyName)
+
+	parts := strings.Split(id, ",")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("malformed ID %q, expected format %s", id, expected)
+	}
+
+	components := make(map[string]string, len(parts))
+	for _, part := range parts {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return "", "", fmt.Errorf("malformed ID %q, expected format %s", id, expected)
+		}
+		components[kv[0]] = kv[1]
+	}
+
+	value, ok := components[keyName]
+	if !ok {
+		return "", "", fmt.Errorf("malformed ID %q, missing %q component, expected format %s", id, keyName, expected)
+	}
+	access, ok := components["access"]
+	if !ok {
+		return "", "", fmt.Errorf("malformed ID %q, missing \"access\" component, expected format %s", id, expected)
+	}
+
+	return value, access, nil
+}
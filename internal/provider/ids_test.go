@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import "testing"
+
+func TestFormatParseCompositeIDRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyName string
+		value   string
+		access  string
+	}{
+		{"simple model", "model", "mymodel", "admin"},
+		{"qualified model", "model", "user@domain/mymodel", "write"},
+		{"offer url with colons", "offer_url", "controller:admin/default.myoffer", "consume"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := formatCompositeID(tt.keyName, tt.value, tt.access)
+
+			value, access, err := parseCompositeID(id, tt.keyName)
+			if err != nil {
+				t.Fatalf("parseCompositeID(%q, %q) returned error: %s", id, tt.keyName, err)
+			}
+			if value != tt.value {
+				t.Errorf("got value %q, want %q", value, tt.value)
+			}
+			if access != tt.access {
+				t.Errorf("got access %q, want %q", access, tt.access)
+			}
+		})
+	}
+}
+
+func TestParseCompositeIDMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		keyName string
+	}{
+		{"no comma", "model=mymodel", "model"},
+		{"too many parts", "model=mymodel,access=admin,extra=foo", "model"},
+		{"missing equals", "model=mymodel,admin", "model"},
+		{"wrong key", "offer_url=controller:admin/default.myoffer,access=read", "model"},
+		{"missing access", "model=mymodel,foo=bar", "model"},
+		{"empty", "", "model"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parseCompositeID(tt.id, tt.keyName); err == nil {
+				t.Errorf("parseCompositeID(%q, %q) succeeded, want error", tt.id, tt.keyName)
+			}
+		})
+	}
+}
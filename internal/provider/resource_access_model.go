@@ -1,217 +1,434 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
 package provider
 
 import (
 	"context"
 	"fmt"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
 	"github.com/juju/terraform-provider-juju/internal/juju"
 )
 
-func resourceAccessModel() *schema.Resource {
-	return &schema.Resource{
-		// This description is used by the documentation generator and the language server.
-		Description: "A resource that represent a Juju Access Model.",
-
-		CreateContext: resourceAccessModelCreate,
-		ReadContext:   resourceAccessModelRead,
-		UpdateContext: resourceAccessModelUpdate,
-		DeleteContext: resourceAccessModelDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &accessModelResource{}
+var _ resource.ResourceWithConfigure = &accessModelResource{}
+var _ resource.ResourceWithImportState = &accessModelResource{}
+var _ resource.ResourceWithConfigValidators = &accessModelResource{}
+
+// NewAccessModelResource returns the juju_access_model resource.
+func NewAccessModelResource() resource.Resource {
+	return &accessModelResource{}
+}
+
+type accessModelResource struct {
+	client *juju.Client
+}
+
+// AccessModelResourceModel describes the terraform resource data model.
+type AccessModelResourceModel struct {
+	Model    types.String         `tfsdk:"model"`
+	Users    types.Set            `tfsdk:"users"`
+	Subjects []AccessSubjectModel `tfsdk:"subjects"`
+	Access   types.String         `tfsdk:"access"`
+	ID       types.String         `tfsdk:"id"`
+}
+
+// AccessSubjectModel describes a single principal granted access, beyond a
+// bare user name.
+type AccessSubjectModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+// Metadata implements resource.Resource.
+func (r *accessModelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_model"
+}
 
-		Schema: map[string]*schema.Schema{
-			"model": {
+// Schema implements resource.Resource.
+func (r *accessModelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A resource that represents a Juju Access Model.",
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
 				Description: "The name of the model for access management",
-				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"users": {
-				Description: "List of users to grant access to",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+			"users": schema.SetAttribute{
+				Description: "Set of users to grant access to. Deprecated in favour of `subjects`, kept for backwards compatibility.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"subjects": schema.SetNestedAttribute{
+				Description: "Set of subjects (users, groups or service accounts) to grant access to",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The kind of subject: user, group or service_account",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("user", "group", "service_account"),
+							},
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the subject",
+							Required:    true,
+						},
+					},
 				},
 			},
-			"access": {
+			"access": schema.StringAttribute{
 				Description: "Type of access to the model",
-				Type:        schema.TypeString,
 				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("admin", "read", "write"),
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
 			},
 		},
 	}
 }
 
-func resourceAccessModelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*juju.Client)
+// ConfigValidators implements resource.ResourceWithConfigValidators.
+func (r *accessModelResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("users"),
+			path.MatchRoot("subjects"),
+		),
+	}
+}
 
-	var diags diag.Diagnostics
+// Configure implements resource.ResourceWithConfigure.
+func (r *accessModelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
 
-	model := d.Get("model").(string)
-	access := d.Get("access").(string)
-	users := d.Get("users").([]string)
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
 
-	uuid, err := client.Models.ResolveModelUUID(model)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *accessModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	var plan AccessModelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subjects, diags := subjectsFromAttributes(ctx, plan.Users, plan.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	modelUUIDs := []string{uuid}
+	model := plan.Model.ValueString()
+	access := plan.Access.ValueString()
 
-	for _, user := range users {
-		err := client.Models.GrantModel(juju.GrantModelInput{
-			User:       user,
-			Access:     access,
-			ModelUUIDs: modelUUIDs,
-		})
-		if err != nil {
-			return diag.FromErr(err)
+	uuid, err := r.client.Models.ResolveModelUUID(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve model %q, got error: %s", model, err))
+		return
+	}
+
+	// Grant every subject even if one of them fails, so the diagnostics
+	// report every failure in a single plan/apply cycle instead of stopping
+	// at the first one.
+	var granted []juju.Subject
+	for _, subject := range subjects {
+		if err := r.client.Models.GrantModel(juju.GrantModelInput{
+			User:      subject.Name,
+			Kind:      subject.Kind,
+			Access:    access,
+			ModelUUID: uuid,
+		}); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant %q access to %s %q, got error: %s", access, subject.Kind, subject.Name, err))
+			continue
 		}
+		granted = append(granted, subject)
 	}
 
-	d.SetId(fmt.Sprintf("%s:%s", model, access))
+	plan.ID = types.StringValue(formatAccessModelID(model, access))
+
+	// Persist whatever access was actually granted above, even if some
+	// subjects failed, so a subsequent apply only has to retry the failures.
+	// Filtering the plan's own users/subjects down to what was granted keeps
+	// each subject under whichever attribute it was originally declared in,
+	// instead of rebucketing by kind and silently moving it.
+	usersSet, subjectsList, setDiags := filterSubjectAttributes(ctx, plan.Users, plan.Subjects, granted)
+	resp.Diagnostics.Append(setDiags...)
+	if setDiags.HasError() {
+		return
+	}
+	plan.Users = usersSet
+	plan.Subjects = subjectsList
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceAccessModelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*juju.Client)
-
-	var diags diag.Diagnostics
+func (r *accessModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
 
-	id := strings.Split(d.Id(), ":")
+	var state AccessModelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	uuid, err := client.Models.ResolveModelUUID(id[0])
+	model, access, err := parseAccessModelID(state.ID.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
 	}
-	response, err := client.Users.ModelUserInfo(uuid)
+
+	uuid, err := r.client.Models.ResolveModelUUID(model)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve model %q, got error: %s", model, err))
+		return
 	}
 
-	if err := d.Set("model", id[0]); err != nil {
-		return diag.FromErr(err)
+	response, err := r.client.Users.ModelUserInfo(uuid)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access model resource, got error: %s", err))
+		return
 	}
-	if err := d.Set("access", id[1]); err != nil {
-		return diag.FromErr(err)
+
+	grantedUsers := make(map[string]bool, len(response.ModelUserInfo))
+	for _, modelUser := range response.ModelUserInfo {
+		if string(modelUser.Access) == access {
+			grantedUsers[modelUser.UserName] = true
+		}
 	}
 
-	users := []string{}
+	stateSubjectList, diags := subjectsFromAttributes(ctx, state.Users, state.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	for _, modelUser := range response.ModelUserInfo {
-		if string(modelUser.Access) == id[1] {
-			users = append(users, modelUser.UserName)
+	// Juju's ModelUserInfo only reports user accounts, not groups or service
+	// accounts, so non-user subjects can't be drift-checked here and are
+	// carried over from state as-is.
+	var subjects []juju.Subject
+	for _, subject := range stateSubjectList {
+		if subject.Kind != juju.SubjectKindUser {
+			subjects = append(subjects, subject)
+			continue
+		}
+		if grantedUsers[subject.Name] {
+			subjects = append(subjects, subject)
 		}
 	}
 
-	if err = d.Set("users", users); err != nil {
-		return diag.FromErr(err)
+	// Filtering state's own users/subjects down to the subjects still granted
+	// keeps each one under whichever attribute it was originally declared
+	// in, instead of rebucketing by kind and silently moving it.
+	usersSet, subjectsList, setDiags := filterSubjectAttributes(ctx, state.Users, state.Subjects, subjects)
+	resp.Diagnostics.Append(setDiags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diags
+	state.Model = types.StringValue(model)
+	state.Access = types.StringValue(access)
+	state.Users = usersSet
+	state.Subjects = subjectsList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-// Updating the access model supports three cases
-// access and users both changed:
-// for missing users - revoke access
-// for changed users - apply new access
-// users changed:
-// for missing users - revoke access
-// for new users - apply access
-// access changed - apply new access
-func resourceAccessModelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*juju.Client)
+// Update computes the grant/revoke/access-level plan via
+// juju.ReconcileAccessUpdate, shared with offerAccessResource's Update.
+func (r *accessModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	var plan, state AccessModelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, _, err := parseAccessModelID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
+	}
 
-	var diags diag.Diagnostics
-	anyChange := false
+	planSubjects, diags := subjectsFromAttributes(ctx, plan.Users, plan.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stateSubjectList, diags := subjectsFromAttributes(ctx, state.Users, state.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// items that could be changed
-	var newAccess string
-	var newUsersList []string
-	var missingUserList []string
+	toGrant, toRevoke, access, changed := juju.ReconcileAccessUpdate(
+		stateSubjectList, planSubjects,
+		state.Access.ValueString(), plan.Access.ValueString(),
+	)
+	if !changed {
+		return
+	}
 
-	var err error
+	uuid, err := r.client.Models.ResolveModelUUID(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve model %q, got error: %s", model, err))
+		return
+	}
 
-	if d.HasChange("users") {
-		anyChange = true
-		oldUsers, newUsers := d.GetChange("users")
-		oldUsersList := oldUsers.([]string)
-		newUsersList = newUsers.([]string)
-		missingUserList = getMissingUsers(oldUsersList, newUsersList)
+	if err := r.client.Models.UpdateAccessModel(juju.UpdateAccessModelInput{
+		ModelUUID: uuid,
+		Grant:     toGrant,
+		Revoke:    toRevoke,
+		Access:    access,
+	}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update access model resource, got error: %s", err))
+		return
 	}
 
-	if d.HasChange("access") {
-		anyChange = true
-		_, accessChanged := d.GetChange("access")
-		newAccess = accessChanged.(string)
+	plan.ID = types.StringValue(formatAccessModelID(model, access))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *accessModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
 	}
 
-	if !anyChange {
-		return diags
+	var state AccessModelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	err = client.Models.UpdateAccessModel(juju.UpdateAccessModelInput{
-		Model:  d.Id(),
-		Grant:  newUsersList,
-		Revoke: missingUserList,
-		Access: newAccess,
-	})
+	model, access, err := parseAccessModelID(state.ID.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
 	}
 
-	if newAccess != "" {
-		id := strings.Split(d.Id(), ":")
-		model := id[0]
-		d.SetId(fmt.Sprintf("%s:%s", model, newAccess))
+	subjects, diags := subjectsFromAttributes(ctx, state.Users, state.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diags
-}
+	uuid, err := r.client.Models.ResolveModelUUID(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve model %q, got error: %s", model, err))
+		return
+	}
 
-func getMissingUsers(oldUsers, newUsers []string) []string {
-	var missing []string
-	for _, user := range oldUsers {
-		found := false
-		for _, newUser := range newUsers {
-			if user == newUser {
-				found = true
-				break
-			}
-		}
-		if !found {
-			missing = append(missing, user)
-		}
+	if err := r.client.Models.DestroyAccessModel(juju.DestroyAccessModelInput{
+		ModelUUID: uuid,
+		Revoke:    subjects,
+		Access:    access,
+	}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete access model resource, got error: %s", err))
 	}
-	return missing
 }
 
-// Juju refers to deletions as "destroy" so we call the Destroy function of our client here rather than delete
-// This function remains named Delete for parity across the provider and to stick within terraform naming conventions
-func resourceAccessModelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*juju.Client)
+// ImportState implements resource.ResourceWithImportState. It validates the
+// structured ID, resolves the model, and pre-populates users/subjects from
+// ModelUserInfo filtered by the requested access level, rather than relying
+// on a bare passthrough that would leave state empty until the next Read.
+func (r *accessModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
 
-	var diags diag.Diagnostics
+	model, access, err := parseAccessModelID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
+	}
 
-	users := d.Get("users").([]string)
-	access := d.Get("access").(string)
+	uuid, err := r.client.Models.ResolveModelUUID(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resolve model %q, got error: %s", model, err))
+		return
+	}
 
-	err := client.Models.DestroyAccessModel(juju.DestroyAccessModelInput{
-		Model:  d.Id(),
-		Revoke: users,
-		Access: access,
-	})
+	response, err := r.client.Users.ModelUserInfo(uuid)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access model resource, got error: %s", err))
+		return
 	}
 
-	d.SetId("")
+	var subjects []juju.Subject
+	for _, modelUser := range response.ModelUserInfo {
+		if string(modelUser.Access) == access {
+			subjects = append(subjects, juju.Subject{Kind: juju.SubjectKindUser, Name: modelUser.UserName})
+		}
+	}
+
+	usersSet, subjectsList, setDiags := bucketSubjects(ctx, subjects)
+	resp.Diagnostics.Append(setDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := AccessModelResourceModel{
+		Model:    types.StringValue(model),
+		Access:   types.StringValue(access),
+		ID:       types.StringValue(formatAccessModelID(model, access)),
+		Users:    usersSet,
+		Subjects: subjectsList,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// formatAccessModelID builds the structured resource ID for an access model
+// resource instance.
+func formatAccessModelID(model, access string) string {
+	return formatCompositeID("model", model, access)
+}
 
-	return diags
+// parseAccessModelID splits the resource ID into the model name and access
+// level it was created with.
+func parseAccessModelID(id string) (string, string, error) {
+	return parseCompositeID(id, "model")
 }
@@ -0,0 +1,379 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &offerAccessResource{}
+var _ resource.ResourceWithConfigure = &offerAccessResource{}
+var _ resource.ResourceWithImportState = &offerAccessResource{}
+var _ resource.ResourceWithConfigValidators = &offerAccessResource{}
+
+// NewOfferAccessResource returns the juju_offer_access resource.
+func NewOfferAccessResource() resource.Resource {
+	return &offerAccessResource{}
+}
+
+type offerAccessResource struct {
+	client *juju.Client
+}
+
+// OfferAccessResourceModel describes the terraform resource data model.
+// It shares its reconciliation engine with AccessModelResourceModel, and
+// differs only in being keyed on an offer URL instead of a model name.
+type OfferAccessResourceModel struct {
+	OfferURL types.String         `tfsdk:"offer_url"`
+	Users    types.Set            `tfsdk:"users"`
+	Subjects []AccessSubjectModel `tfsdk:"subjects"`
+	Access   types.String         `tfsdk:"access"`
+	ID       types.String         `tfsdk:"id"`
+}
+
+// Metadata implements resource.Resource.
+func (r *offerAccessResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_offer_access"
+}
+
+// Schema implements resource.Resource.
+func (r *offerAccessResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A resource that represents access to a Juju Offer.",
+		Attributes: map[string]schema.Attribute{
+			"offer_url": schema.StringAttribute{
+				Description: "The URL of the offer to manage access for",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"users": schema.SetAttribute{
+				Description: "Set of users to grant access to. Deprecated in favour of `subjects`, kept for backwards compatibility.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"subjects": schema.SetNestedAttribute{
+				Description: "Set of subjects (users, groups or service accounts) to grant access to",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The kind of subject: user, group or service_account",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("user", "group", "service_account"),
+							},
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the subject",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"access": schema.StringAttribute{
+				Description: "Type of access to the offer",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("admin", "consume", "read"),
+				},
+			},
+			// ID required by the testing framework
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators.
+func (r *offerAccessResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("users"),
+			path.MatchRoot("subjects"),
+		),
+	}
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *offerAccessResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*juju.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *juju.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *offerAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	var plan OfferAccessResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subjects, diags := subjectsFromAttributes(ctx, plan.Users, plan.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	offerURL := plan.OfferURL.ValueString()
+	access := plan.Access.ValueString()
+
+	if err := r.client.Offers.Grant(juju.GrantRevokeOfferInput{
+		Subjects: subjects,
+		Access:   access,
+		OfferURL: offerURL,
+	}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant %q access to offer %q, got error: %s", access, offerURL, err))
+		return
+	}
+
+	plan.ID = types.StringValue(formatOfferAccessID(offerURL, access))
+	// Filtering the plan's own users/subjects down to what was granted keeps
+	// each subject under whichever attribute it was originally declared in,
+	// instead of rebucketing by kind and silently moving it.
+	usersSet, subjectsList, setDiags := filterSubjectAttributes(ctx, plan.Users, plan.Subjects, subjects)
+	resp.Diagnostics.Append(setDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Users = usersSet
+	plan.Subjects = subjectsList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *offerAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	var state OfferAccessResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	offerURL, access, err := parseOfferAccessID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
+	}
+
+	info, err := r.client.Offers.Info(offerURL)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read offer access resource, got error: %s", err))
+		return
+	}
+
+	var subjects []juju.Subject
+	for _, accessInfo := range info.Access {
+		if accessInfo.Access == access {
+			subjects = append(subjects, accessInfo.Subject)
+		}
+	}
+
+	// Filtering state's own users/subjects down to the subjects still
+	// granted keeps each one under whichever attribute it was originally
+	// declared in, instead of rebucketing by kind and silently moving it.
+	usersSet, subjectsList, setDiags := filterSubjectAttributes(ctx, state.Users, state.Subjects, subjects)
+	resp.Diagnostics.Append(setDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.OfferURL = types.StringValue(offerURL)
+	state.Access = types.StringValue(access)
+	state.Users = usersSet
+	state.Subjects = subjectsList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update computes the grant/revoke/access-level plan via
+// juju.ReconcileAccessUpdate, shared with accessModelResource's Update.
+func (r *offerAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	var plan, state OfferAccessResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	offerURL, _, err := parseOfferAccessID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
+	}
+
+	planSubjects, diags := subjectsFromAttributes(ctx, plan.Users, plan.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stateSubjectList, diags := subjectsFromAttributes(ctx, state.Users, state.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toGrant, toRevoke, access, changed := juju.ReconcileAccessUpdate(
+		stateSubjectList, planSubjects,
+		state.Access.ValueString(), plan.Access.ValueString(),
+	)
+	if !changed {
+		return
+	}
+
+	if len(toRevoke) > 0 {
+		if err := r.client.Offers.Revoke(juju.GrantRevokeOfferInput{
+			Subjects: toRevoke,
+			Access:   access,
+			OfferURL: offerURL,
+		}); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update offer access resource, got error: %s", err))
+			return
+		}
+	}
+
+	if len(toGrant) > 0 {
+		if err := r.client.Offers.Grant(juju.GrantRevokeOfferInput{
+			Subjects: toGrant,
+			Access:   access,
+			OfferURL: offerURL,
+		}); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update offer access resource, got error: %s", err))
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(formatOfferAccessID(offerURL, access))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *offerAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	var state OfferAccessResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	offerURL, access, err := parseOfferAccessID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
+	}
+
+	subjects, diags := subjectsFromAttributes(ctx, state.Users, state.Subjects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.Offers.Revoke(juju.GrantRevokeOfferInput{
+		Subjects: subjects,
+		Access:   access,
+		OfferURL: offerURL,
+	}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete offer access resource, got error: %s", err))
+	}
+}
+
+// ImportState implements resource.ResourceWithImportState. It validates the
+// structured ID, then pre-populates users/subjects from Offers.Info filtered
+// by the requested access level, mirroring accessModelResource's importer.
+func (r *offerAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider Error", "The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource.")
+		return
+	}
+
+	offerURL, access, err := parseOfferAccessID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("ID Error", err.Error())
+		return
+	}
+
+	info, err := r.client.Offers.Info(offerURL)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read offer access resource, got error: %s", err))
+		return
+	}
+
+	var subjects []juju.Subject
+	for _, accessInfo := range info.Access {
+		if accessInfo.Access == access {
+			subjects = append(subjects, accessInfo.Subject)
+		}
+	}
+
+	usersSet, subjectsList, setDiags := bucketSubjects(ctx, subjects)
+	resp.Diagnostics.Append(setDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := OfferAccessResourceModel{
+		OfferURL: types.StringValue(offerURL),
+		Access:   types.StringValue(access),
+		ID:       types.StringValue(formatOfferAccessID(offerURL, access)),
+		Users:    usersSet,
+		Subjects: subjectsList,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// formatOfferAccessID builds the structured resource ID for an offer access
+// resource instance.
+func formatOfferAccessID(offerURL, access string) string {
+	return formatCompositeID("offer_url", offerURL, access)
+}
+
+// parseOfferAccessID splits the resource ID into the offer URL and access
+// level it was created with.
+func parseOfferAccessID(id string) (string, string, error) {
+	return parseCompositeID(id, "offer_url")
+}
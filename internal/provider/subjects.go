@@ -0,0 +1,104 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+// subjectsFromAttributes merges the deprecated plain `users` set and the
+// `subjects` block into a single list of subjects to reconcile. It is shared
+// by every resource that accepts both attributes (the access model and
+// offer access resources).
+func subjectsFromAttributes(ctx context.Context, users types.Set, subjects []AccessSubjectModel) ([]juju.Subject, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var userNames []string
+	diags.Append(users.ElementsAs(ctx, &userNames, false)...)
+
+	result := make([]juju.Subject, 0, len(userNames)+len(subjects))
+	for _, user := range userNames {
+		result = append(result, juju.Subject{Kind: juju.SubjectKindUser, Name: user})
+	}
+	for _, subject := range subjects {
+		result = append(result, juju.Subject{
+			Kind: juju.SubjectKind(subject.Type.ValueString()),
+			Name: subject.Name.ValueString(),
+		})
+	}
+
+	return result, diags
+}
+
+// bucketSubjects splits a subject list into the `users` set and `subjects`
+// slice representation, routing every user-kind subject into `users`. It has
+// no way to tell whether a user-kind subject was originally declared via
+// `subjects` instead, so it must only be used when there's no prior state to
+// preserve the split from, i.e. ImportState.
+func bucketSubjects(ctx context.Context, subjects []juju.Subject) (types.Set, []AccessSubjectModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var users []string
+	var others []AccessSubjectModel
+	for _, subject := range subjects {
+		if subject.Kind == juju.SubjectKindUser {
+			users = append(users, subject.Name)
+			continue
+		}
+		others = append(others, AccessSubjectModel{
+			Type: types.StringValue(string(subject.Kind)),
+			Name: types.StringValue(subject.Name),
+		})
+	}
+
+	usersSet, setDiags := types.SetValueFrom(ctx, types.StringType, users)
+	diags.Append(setDiags...)
+
+	return usersSet, others, diags
+}
+
+// filterSubjectAttributes returns the subset of the given `users`/`subjects`
+// attributes whose subject appears in kept, preserving which attribute each
+// one was originally declared under. It is the round-trip-safe counterpart
+// to bucketSubjects: Create and Read both already have the original
+// users/subjects attributes in hand (from the plan or from prior state), so
+// rather than re-deriving the split from scratch by subject kind - which
+// would move a user-kind entry declared under `subjects` into `users` - they
+// filter that original split down to whatever is still present.
+func filterSubjectAttributes(ctx context.Context, users types.Set, subjects []AccessSubjectModel, kept []juju.Subject) (types.Set, []AccessSubjectModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	keep := make(map[juju.Subject]bool, len(kept))
+	for _, subject := range kept {
+		keep[subject] = true
+	}
+
+	var userNames []string
+	diags.Append(users.ElementsAs(ctx, &userNames, false)...)
+
+	var filteredUsers []string
+	for _, user := range userNames {
+		if keep[juju.Subject{Kind: juju.SubjectKindUser, Name: user}] {
+			filteredUsers = append(filteredUsers, user)
+		}
+	}
+
+	var filteredSubjects []AccessSubjectModel
+	for _, subject := range subjects {
+		s := juju.Subject{Kind: juju.SubjectKind(subject.Type.ValueString()), Name: subject.Name.ValueString()}
+		if keep[s] {
+			filteredSubjects = append(filteredSubjects, subject)
+		}
+	}
+
+	usersSet, setDiags := types.SetValueFrom(ctx, types.StringType, filteredUsers)
+	diags.Append(setDiags...)
+
+	return usersSet, filteredSubjects, diags
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the Apache License, Version 2.0, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/juju/terraform-provider-juju/internal/juju"
+)
+
+func mustUsersSet(t *testing.T, users ...string) types.Set {
+	t.Helper()
+	set, diags := types.SetValueFrom(context.Background(), types.StringType, users)
+	if diags.HasError() {
+		t.Fatalf("building users set: %v", diags)
+	}
+	return set
+}
+
+func TestFilterSubjectAttributesPreservesOrigin(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		users        types.Set
+		subjects     []AccessSubjectModel
+		kept         []juju.Subject
+		wantUsers    []string
+		wantSubjects []AccessSubjectModel
+	}{
+		{
+			// This is the scenario the inconsistent-result bug hid in: a
+			// user-kind subject declared only via `subjects`, with `users`
+			// left unset, must stay in `subjects` after filtering rather
+			// than being rebucketed into `users`.
+			name:     "user declared via subjects only is not moved to users",
+			users:    mustUsersSet(t),
+			subjects: []AccessSubjectModel{{Type: types.StringValue("user"), Name: types.StringValue("alice")}},
+			kept:     []juju.Subject{{Kind: juju.SubjectKindUser, Name: "alice"}},
+			wantSubjects: []AccessSubjectModel{
+				{Type: types.StringValue("user"), Name: types.StringValue("alice")},
+			},
+		},
+		{
+			name:      "user declared via users stays in users",
+			users:     mustUsersSet(t, "alice"),
+			subjects:  nil,
+			kept:      []juju.Subject{{Kind: juju.SubjectKindUser, Name: "alice"}},
+			wantUsers: []string{"alice"},
+		},
+		{
+			name:      "subject dropped when not kept",
+			users:     mustUsersSet(t, "alice"),
+			subjects:  []AccessSubjectModel{{Type: types.StringValue("user"), Name: types.StringValue("bob")}},
+			kept:      []juju.Subject{{Kind: juju.SubjectKindUser, Name: "alice"}},
+			wantUsers: []string{"alice"},
+		},
+		{
+			name:     "group subject preserved",
+			users:    mustUsersSet(t),
+			subjects: []AccessSubjectModel{{Type: types.StringValue("group"), Name: types.StringValue("ops")}},
+			kept:     []juju.Subject{{Kind: juju.SubjectKindGroup, Name: "ops"}},
+			wantSubjects: []AccessSubjectModel{
+				{Type: types.StringValue("group"), Name: types.StringValue("ops")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersSet, subjects, diags := filterSubjectAttributes(ctx, tt.users, tt.subjects, tt.kept)
+			if diags.HasError() {
+				t.Fatalf("filterSubjectAttributes returned diagnostics: %v", diags)
+			}
+
+			var gotUsers []string
+			diags = usersSet.ElementsAs(ctx, &gotUsers, false)
+			if diags.HasError() {
+				t.Fatalf("reading back users set: %v", diags)
+			}
+
+			if len(gotUsers) != len(tt.wantUsers) {
+				t.Errorf("users = %v, want %v", gotUsers, tt.wantUsers)
+			}
+			for _, want := range tt.wantUsers {
+				found := false
+				for _, got := range gotUsers {
+					if got == want {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("users = %v, want to contain %q", gotUsers, want)
+				}
+			}
+
+			if len(subjects) != len(tt.wantSubjects) {
+				t.Fatalf("subjects = %v, want %v", subjects, tt.wantSubjects)
+			}
+			for i, want := range tt.wantSubjects {
+				if !subjects[i].Type.Equal(want.Type) || !subjects[i].Name.Equal(want.Name) {
+					t.Errorf("subjects[%d] = %+v, want %+v", i, subjects[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSubjectsFromAttributesFilterSubjectAttributesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	users := mustUsersSet(t, "alice")
+	subjects := []AccessSubjectModel{
+		{Type: types.StringValue("user"), Name: types.StringValue("bob")},
+		{Type: types.StringValue("group"), Name: types.StringValue("ops")},
+	}
+
+	merged, diags := subjectsFromAttributes(ctx, users, subjects)
+	if diags.HasError() {
+		t.Fatalf("subjectsFromAttributes returned diagnostics: %v", diags)
+	}
+
+	usersSet, gotSubjects, diags := filterSubjectAttributes(ctx, users, subjects, merged)
+	if diags.HasError() {
+		t.Fatalf("filterSubjectAttributes returned diagnostics: %v", diags)
+	}
+
+	var gotUsers []string
+	diags = usersSet.ElementsAs(ctx, &gotUsers, false)
+	if diags.HasError() {
+		t.Fatalf("reading back users set: %v", diags)
+	}
+	if len(gotUsers) != 1 || gotUsers[0] != "alice" {
+		t.Errorf("users = %v, want [alice]", gotUsers)
+	}
+	if len(gotSubjects) != 2 {
+		t.Fatalf("subjects = %v, want 2 entries", gotSubjects)
+	}
+}